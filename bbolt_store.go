@@ -0,0 +1,109 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var bboltDedupBucket = []byte("notifier_dedup")
+
+// BboltStore is a DedupStore backed by a bbolt key/value file, safe for concurrent
+// goroutines within a single process
+type BboltStore struct {
+	db *bbolt.DB
+}
+
+// NewBboltStore opens (creating if needed) a bbolt database at path
+func NewBboltStore(path string) (*BboltStore, error) {
+	db, err := bbolt.Open(path, 0644, nil)
+	if err != nil {
+		return nil, fmt.Errorf("BboltStore failed to open %s: %s", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bboltDedupBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("BboltStore failed to create bucket: %s", err)
+	}
+
+	return &BboltStore{db: db}, nil
+}
+
+type bboltDedupEntry struct {
+	Subject   string    `json:"subject"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Seen reports whether hash has an unexpired entry
+func (store *BboltStore) Seen(hash string) bool {
+	var seen bool
+	store.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(bboltDedupBucket).Get([]byte(hash))
+		if raw == nil {
+			return nil
+		}
+
+		var entry bboltDedupEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil
+		}
+
+		seen = entry.ExpiresAt.IsZero() || time.Now().Before(entry.ExpiresAt)
+		return nil
+	})
+
+	return seen
+}
+
+// Claim atomically checks hash and, if it has no unexpired entry, marks it as seen;
+// the check and the Put run inside a single bbolt write transaction, closing the
+// window where two goroutines could both observe hash as unseen and both send
+func (store *BboltStore) Claim(hash string, subject string, ttl time.Duration) (bool, error) {
+	var won bool
+
+	err := store.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bboltDedupBucket)
+
+		if raw := bucket.Get([]byte(hash)); raw != nil {
+			var entry bboltDedupEntry
+			if err := json.Unmarshal(raw, &entry); err == nil {
+				if entry.ExpiresAt.IsZero() || time.Now().Before(entry.ExpiresAt) {
+					return nil
+				}
+			}
+		}
+
+		entry := bboltDedupEntry{Subject: subject}
+		if ttl > 0 {
+			entry.ExpiresAt = time.Now().Add(ttl)
+		}
+
+		raw, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+
+		if err := bucket.Put([]byte(hash), raw); err != nil {
+			return err
+		}
+
+		won = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("BboltStore failed to claim %s: %s", hash, err)
+	}
+
+	return won, nil
+}
+
+// Close releases the underlying bbolt file handle
+func (store *BboltStore) Close() error {
+	return store.db.Close()
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestBboltStore(t *testing.T) *BboltStore {
+	t.Helper()
+
+	store, err := NewBboltStore(filepath.Join(t.TempDir(), "dedup.bbolt"))
+	if err != nil {
+		t.Fatalf("NewBboltStore: %s", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestBboltStoreClaim(t *testing.T) {
+	store := newTestBboltStore(t)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("first Claim should win")
+	}
+
+	won, err = store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if won {
+		t.Fatal("second Claim before expiry should lose")
+	}
+
+	if !store.Seen("hash") {
+		t.Fatal("Seen should report the claimed hash")
+	}
+}
+
+func TestBboltStoreClaimExpiry(t *testing.T) {
+	store := newTestBboltStore(t)
+
+	if _, err := store.Claim("hash", "subject", time.Millisecond); err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("Claim should win again once the prior entry expired")
+	}
+}
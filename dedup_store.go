@@ -0,0 +1,15 @@
+package notifier
+
+import "time"
+
+// DedupStore tracks which hashes have already been notified, expiring entries after a TTL
+type DedupStore interface {
+	// Seen reports whether hash has an unexpired entry; it is a non-authoritative
+	// pre-check only, since a Seen()==false result can race with a concurrent Claim
+	Seen(hash string) bool
+	// Claim atomically checks hash and, if it has no unexpired entry, marks it as seen
+	// with subject and expiring after ttl (zero means never). It reports whether this
+	// call won the race and should proceed to send; a losing caller must not send.
+	Claim(hash string, subject string, ttl time.Duration) (won bool, err error)
+	Close() error
+}
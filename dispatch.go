@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+)
+
+// Dispatch renders n for each recipient in Recipients (or DefaultRecipients when
+// Recipients is empty) and fans it out to whichever channels the recipient routes
+// to, after a per-recipient priority range filter and a ShouldNotify veto. The
+// webhook channel is recipient-agnostic (it always posts to the single configured
+// WebhookSender), so it fires at most once per Dispatch call rather than once per
+// recipient. Like SendMessage, repeat calls for the same Notification.Name are
+// deduplicated by MessageTag with Frequency.
+func (notifier Notifier) Dispatch(n Notification) error {
+	recipients := notifier.Recipients
+	if len(recipients) == 0 {
+		recipients = notifier.DefaultRecipients
+	}
+
+	if len(recipients) == 0 {
+		return errors.New("Notifier failed to dispatch notification: no recipients")
+	}
+
+	if notifier.Log == nil {
+		notifier.Log = log.New(os.Stdout, "Notifier ", log.LstdFlags)
+	}
+
+	hash := notifier.getNotificationHash(n)
+	won, err := notifier.claim(hash, n.Name())
+	if err != nil {
+		notifier.Log.Printf("Notifier failed to claim %s: %s", n.Name(), err)
+		return err
+	}
+	if !won {
+		notifier.Log.Printf("Skip notification %s: %s", hash, n.Name())
+		return nil
+	}
+
+	ctx := context.Background()
+	var lastErr error
+	var wantWebhook bool
+
+	for _, recipient := range recipients {
+		if !notifier.inPriorityRange(n, recipient) {
+			continue
+		}
+
+		should, err := recipient.ShouldNotify(n)
+		if err != nil {
+			notifier.Log.Printf("Notifier failed to check ShouldNotify for %s: %s", n.Name(), err)
+			lastErr = err
+			continue
+		}
+		if !should {
+			continue
+		}
+
+		if mail := n.ToMail(); mail != nil {
+			if err := notifier.dispatchMail(ctx, recipient, mail); err != nil {
+				notifier.Log.Printf("Notifier failed to dispatch %s by mail: %s", n.Name(), err)
+				lastErr = err
+			}
+		}
+
+		if push := n.ToPush(); push != nil {
+			if err := notifier.dispatchPush(ctx, recipient, push); err != nil {
+				notifier.Log.Printf("Notifier failed to dispatch %s by push: %s", n.Name(), err)
+				lastErr = err
+			}
+		}
+
+		if n.ToWebhook() != nil {
+			wantWebhook = true
+		}
+	}
+
+	if wantWebhook {
+		if err := notifier.dispatchWebhook(ctx, n.ToWebhook()); err != nil {
+			notifier.Log.Printf("Notifier failed to dispatch %s by webhook: %s", n.Name(), err)
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+func (notifier Notifier) inPriorityRange(n Notification, recipient Notifiable) bool {
+	ranged, ok := recipient.(PriorityRangeNotifiable)
+	if !ok {
+		return true
+	}
+
+	prioritized, ok := n.(PrioritizedNotification)
+	if !ok {
+		return true
+	}
+
+	min, max := ranged.MaxPrioIn()
+	priority := prioritized.Priority()
+
+	return priority >= min && priority <= max
+}
+
+func (notifier Notifier) dispatchMail(ctx context.Context, recipient Notifiable, mail *Mail) error {
+	if notifier.Sender == nil {
+		return errors.New("Sender is not set")
+	}
+
+	address, err := recipient.RouteForMail()
+	if err != nil {
+		return err
+	}
+	if address == "" {
+		return nil
+	}
+
+	msg := Message{Event: EventMessage, Title: mail.Subject, Body: mail.Body, HTMLBody: mail.HTML}
+	return notifier.Sender.Send(ctx, msg, []Recipient{{Address: address}})
+}
+
+func (notifier Notifier) dispatchPush(ctx context.Context, recipient Notifiable, push *Push) error {
+	if notifier.PushSender == nil {
+		return errors.New("PushSender is not set")
+	}
+
+	token, platform, err := recipient.RouteForPush()
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return nil
+	}
+
+	msg := Message{Event: EventMessage, Title: push.Title, Body: push.Body}
+	return notifier.PushSender.Push(ctx, msg, []Device{{Token: token, Platform: platform}})
+}
+
+func (notifier Notifier) dispatchWebhook(ctx context.Context, webhook *Webhook) error {
+	if notifier.WebhookSender == nil {
+		return errors.New("WebhookSender is not set")
+	}
+
+	msg := Message{Event: EventMessage, Title: webhook.Subject, Body: webhook.Body}
+	return notifier.WebhookSender.Send(ctx, msg, nil)
+}
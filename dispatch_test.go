@@ -0,0 +1,82 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeDispatchSender struct {
+	calls []string
+}
+
+func (s *fakeDispatchSender) Send(ctx context.Context, msg Message, recipients []Recipient) error {
+	for _, recipient := range recipients {
+		s.calls = append(s.calls, recipient.Address)
+	}
+	return nil
+}
+
+type fakeNotification struct {
+	name     string
+	priority int
+	mail     *Mail
+}
+
+func (n *fakeNotification) Name() string        { return n.name }
+func (n *fakeNotification) ToMail() *Mail       { return n.mail }
+func (n *fakeNotification) ToPush() *Push       { return nil }
+func (n *fakeNotification) ToWebhook() *Webhook { return nil }
+func (n *fakeNotification) Priority() int       { return n.priority }
+
+type fakeRecipient struct {
+	address          string
+	minPrio, maxPrio int
+}
+
+func (r *fakeRecipient) RouteForMail() (string, error)             { return r.address, nil }
+func (r *fakeRecipient) RouteForPush() (string, int, error)        { return "", 0, nil }
+func (r *fakeRecipient) ShouldNotify(n Notification) (bool, error) { return true, nil }
+func (r *fakeRecipient) MaxPrioIn() (int, int)                     { return r.minPrio, r.maxPrio }
+
+func TestDispatchUsesDefaultRecipientsWhenRecipientsEmpty(t *testing.T) {
+	sender := &fakeDispatchSender{}
+	recipient := &fakeRecipient{address: "ops@example.com", minPrio: 0, maxPrio: 5}
+
+	notifier := Notifier{
+		Sender:            sender,
+		Frequency:         NotifyAlways,
+		DefaultRecipients: []Notifiable{recipient},
+	}
+
+	n := &fakeNotification{name: "disk-full", priority: 3, mail: &Mail{Subject: "disk full", Body: "body"}}
+
+	if err := notifier.Dispatch(n); err != nil {
+		t.Fatalf("Dispatch: %s", err)
+	}
+
+	if len(sender.calls) != 1 || sender.calls[0] != "ops@example.com" {
+		t.Fatalf("expected DefaultRecipients to receive the mail, got %v", sender.calls)
+	}
+}
+
+func TestDispatchFiltersOutOfPriorityRangeRecipients(t *testing.T) {
+	sender := &fakeDispatchSender{}
+	inRange := &fakeRecipient{address: "oncall@example.com", minPrio: 3, maxPrio: 5}
+	outOfRange := &fakeRecipient{address: "digest@example.com", minPrio: 0, maxPrio: 2}
+
+	notifier := Notifier{
+		Sender:     sender,
+		Frequency:  NotifyAlways,
+		Recipients: []Notifiable{inRange, outOfRange},
+	}
+
+	n := &fakeNotification{name: "disk-full", priority: 4, mail: &Mail{Subject: "disk full", Body: "body"}}
+
+	if err := notifier.Dispatch(n); err != nil {
+		t.Fatalf("Dispatch: %s", err)
+	}
+
+	if len(sender.calls) != 1 || sender.calls[0] != "oncall@example.com" {
+		t.Fatalf("expected only the in-range recipient to receive the mail, got %v", sender.calls)
+	}
+}
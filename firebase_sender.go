@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"google.golang.org/api/option"
+)
+
+// FirebaseSender delivers push notifications through the Firebase Cloud Messaging Admin SDK,
+// an alternative to GorushSender for callers who want to talk to FCM directly
+type FirebaseSender struct {
+	CredentialsFile string // path to a Firebase service account JSON key
+
+	app *firebase.App
+}
+
+// Push implements PushSender by sending one FCM message per device token
+func (sender *FirebaseSender) Push(ctx context.Context, msg Message, devices []Device) error {
+	client, err := sender.client(ctx)
+	if err != nil {
+		return fmt.Errorf("FirebaseSender failed to send push: %s", err)
+	}
+
+	var failed []string
+	for _, device := range devices {
+		_, err := client.Send(ctx, &messaging.Message{
+			Token: device.Token,
+			Notification: &messaging.Notification{
+				Title: msg.Title,
+				Body:  msg.Body,
+			},
+		})
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", device.Token, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("FirebaseSender failed to deliver to %d token(s): %s", len(failed), failed)
+	}
+
+	return nil
+}
+
+func (sender *FirebaseSender) client(ctx context.Context) (*messaging.Client, error) {
+	if sender.app == nil {
+		app, err := firebase.NewApp(ctx, nil, option.WithCredentialsFile(sender.CredentialsFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to init Firebase app: %s", err)
+		}
+		sender.app = app
+	}
+
+	return sender.app.Messaging(ctx)
+}
@@ -0,0 +1,142 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	IOS     int = 1
+	Android int = 2
+)
+
+// Device identifies a mobile push notification target
+type Device struct {
+	Token    string
+	Platform int    // IOS or Android
+	Topic    string // iOS APNs topic / bundle id, ignored on Android
+}
+
+func (Device) isTarget() {}
+
+// GorushSender delivers push notifications through a gorush server's HTTP API
+type GorushSender struct {
+	ServerURL string // e.g. "http://localhost:8088"
+	Priority  string // gorush priority: "normal" or "high", default "high"
+}
+
+type gorushNotification struct {
+	Tokens   []string `json:"tokens"`
+	Platform int      `json:"platform"`
+	Message  string   `json:"message"`
+	Title    string   `json:"title,omitempty"`
+	Priority string   `json:"priority,omitempty"`
+	Topic    string   `json:"topic,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+type gorushRequest struct {
+	Notifications []gorushNotification `json:"notifications"`
+}
+
+type gorushLog struct {
+	Type  string `json:"type"`
+	Token string `json:"token"`
+	Error string `json:"error"`
+}
+
+type gorushResponse struct {
+	Logs []gorushLog `json:"logs"`
+}
+
+// Push implements PushSender by POSTing to the gorush /api/push endpoint,
+// grouping devices by platform and APNs topic into separate notifications
+func (sender *GorushSender) Push(ctx context.Context, msg Message, devices []Device) error {
+	sender.setDefaults()
+
+	body, err := json.Marshal(gorushRequest{Notifications: sender.buildNotifications(msg, devices)})
+	if err != nil {
+		return fmt.Errorf("GorushSender failed to encode request: %s", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", sender.ServerURL+"/api/push", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("GorushSender failed to build request: %s", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("GorushSender failed to send push: %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("GorushSender failed to send push: unexpected status %s", response.Status)
+	}
+
+	var result gorushResponse
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return fmt.Errorf("GorushSender failed to decode response: %s", err)
+	}
+
+	var failed []string
+	for _, l := range result.Logs {
+		if l.Type == "failed-push" {
+			failed = append(failed, fmt.Sprintf("%s: %s", l.Token, l.Error))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("GorushSender failed to deliver to %d token(s): %s", len(failed), failed)
+	}
+
+	return nil
+}
+
+type gorushGroupKey struct {
+	platform int
+	topic    string
+}
+
+func (sender *GorushSender) buildNotifications(msg Message, devices []Device) []gorushNotification {
+	priority := sender.Priority
+	if msg.Priority >= 4 {
+		priority = "high"
+	} else if msg.Priority >= 1 {
+		priority = "normal"
+	}
+
+	groups := make(map[gorushGroupKey][]string)
+	var order []gorushGroupKey
+	for _, device := range devices {
+		key := gorushGroupKey{platform: device.Platform, topic: device.Topic}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], device.Token)
+	}
+
+	notifications := make([]gorushNotification, 0, len(order))
+	for _, key := range order {
+		notifications = append(notifications, gorushNotification{
+			Tokens:   groups[key],
+			Platform: key.platform,
+			Message:  msg.Body,
+			Title:    msg.Title,
+			Priority: priority,
+			Topic:    key.topic,
+			Tags:     msg.Tags,
+		})
+	}
+
+	return notifications
+}
+
+func (sender *GorushSender) setDefaults() {
+	if sender.Priority == "" {
+		sender.Priority = "high"
+	}
+}
@@ -0,0 +1,157 @@
+package notifier
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// jsonDedupEntry is one row of a JSONFileStore: the original subject plus when it expires
+type jsonDedupEntry struct {
+	Subject   string    `json:"subject"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// JSONFileStore is a DedupStore backed by a single JSON file. Writes go through a
+// lock file held for the duration of the read-modify-write and are published with
+// a temp file + rename, so concurrent goroutines or processes can't corrupt it.
+type JSONFileStore struct {
+	Path string
+}
+
+// NewJSONFileStore builds a JSONFileStore at path; the file is created on the first successful Claim
+func NewJSONFileStore(path string) *JSONFileStore {
+	return &JSONFileStore{Path: path}
+}
+
+// Seen reports whether hash has an unexpired entry
+func (store *JSONFileStore) Seen(hash string) bool {
+	db, err := store.read()
+	if err != nil {
+		return false
+	}
+
+	entry, ok := db[hash]
+	if !ok {
+		return false
+	}
+
+	return entry.ExpiresAt.IsZero() || time.Now().Before(entry.ExpiresAt)
+}
+
+// Claim atomically checks hash and, if it has no unexpired entry, marks it as seen;
+// the lock held across the read-modify-write makes the check-and-set atomic, closing
+// the window where two callers could both observe hash as unseen and both send
+func (store *JSONFileStore) Claim(hash string, subject string, ttl time.Duration) (bool, error) {
+	lock, err := store.lock()
+	if err != nil {
+		return false, fmt.Errorf("JSONFileStore failed to claim %s: %s", hash, err)
+	}
+	defer lock.unlock()
+
+	db, err := store.read()
+	if err != nil {
+		return false, fmt.Errorf("JSONFileStore failed to claim %s: %s", hash, err)
+	}
+
+	if _, ok := db[hash]; ok {
+		return false, nil
+	}
+
+	entry := jsonDedupEntry{Subject: subject}
+	if ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+	db[hash] = entry
+
+	if err := store.write(db); err != nil {
+		return false, fmt.Errorf("JSONFileStore failed to claim %s: %s", hash, err)
+	}
+
+	return true, nil
+}
+
+// Close is a no-op; JSONFileStore holds no long-lived handle
+func (store *JSONFileStore) Close() error {
+	return nil
+}
+
+func (store *JSONFileStore) read() (map[string]jsonDedupEntry, error) {
+	db := make(map[string]jsonDedupEntry)
+
+	file, err := os.Open(store.Path)
+	if os.IsNotExist(err) {
+		return db, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database file %s: %s", store.Path, err)
+	}
+	defer file.Close()
+
+	if err := json.NewDecoder(file).Decode(&db); err != nil {
+		return nil, fmt.Errorf("failed to decode database file %s: %s", store.Path, err)
+	}
+
+	now := time.Now()
+	for hash, entry := range db {
+		if !entry.ExpiresAt.IsZero() && now.After(entry.ExpiresAt) {
+			delete(db, hash)
+		}
+	}
+
+	return db, nil
+}
+
+func (store *JSONFileStore) write(db map[string]jsonDedupEntry) error {
+	dir := filepath.Dir(store.Path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(store.Path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp database file: %s", err)
+	}
+	tmpPath := tmp.Name()
+
+	if err := json.NewEncoder(tmp).Encode(db); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to encode database file: %s", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp database file: %s", err)
+	}
+
+	if err := os.Rename(tmpPath, store.Path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace database file %s: %s", store.Path, err)
+	}
+
+	return nil
+}
+
+// jsonFileLock holds an exclusive flock on a sidecar lock file, kept separate from
+// the data file so it stays valid across the data file's rename-on-write
+type jsonFileLock struct {
+	file *os.File
+}
+
+func (store *JSONFileStore) lock() (*jsonFileLock, error) {
+	file, err := os.OpenFile(store.Path+".lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %s.lock: %s", store.Path, err)
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s.lock: %s", store.Path, err)
+	}
+
+	return &jsonFileLock{file: file}, nil
+}
+
+func (lock *jsonFileLock) unlock() {
+	syscall.Flock(int(lock.file.Fd()), syscall.LOCK_UN)
+	lock.file.Close()
+}
@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONFileStoreClaim(t *testing.T) {
+	store := NewJSONFileStore(filepath.Join(t.TempDir(), "dedup.json"))
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("first Claim should win")
+	}
+
+	won, err = store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if won {
+		t.Fatal("second Claim before expiry should lose")
+	}
+
+	if !store.Seen("hash") {
+		t.Fatal("Seen should report the claimed hash")
+	}
+}
+
+func TestJSONFileStoreClaimExpiry(t *testing.T) {
+	store := NewJSONFileStore(filepath.Join(t.TempDir(), "dedup.json"))
+
+	if _, err := store.Claim("hash", "subject", time.Millisecond); err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("Claim should win again once the prior entry expired")
+	}
+}
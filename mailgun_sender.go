@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MailgunSender delivers email notifications through the Mailgun HTTP API
+type MailgunSender struct {
+	ApiBase string // Default is "https://api.mailgun.net/v3"
+	Domain  string // Mailgun sending domain, required
+	ApiKey  string // Mailgun private API key, required
+	From    Recipient
+}
+
+// Send implements Sender by POSTing a form-encoded message to the Mailgun messages endpoint
+func (sender *MailgunSender) Send(ctx context.Context, msg Message, recipients []Recipient) error {
+	sender.setDefaults()
+
+	to := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		to = append(to, recipient.Address)
+	}
+
+	form := url.Values{}
+	form.Set("from", fmt.Sprintf("%s <%s>", sender.From.Title, sender.From.Address))
+	form.Set("to", strings.Join(to, ","))
+	form.Set("subject", msg.Title)
+	form.Set("text", msg.Body)
+	if msg.HTMLBody != "" {
+		form.Set("html", msg.HTMLBody)
+	}
+	for _, tag := range msg.Tags {
+		form.Add("o:tag", tag)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/messages", sender.ApiBase, sender.Domain)
+	request, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("MailgunSender failed to build request: %s", err)
+	}
+	request.SetBasicAuth("api", sender.ApiKey)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("MailgunSender failed to send message: %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("MailgunSender failed to send message: unexpected status %s", response.Status)
+	}
+
+	return nil
+}
+
+func (sender *MailgunSender) setDefaults() {
+	if sender.ApiBase == "" {
+		sender.ApiBase = "https://api.mailgun.net/v3"
+	}
+}
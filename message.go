@@ -0,0 +1,32 @@
+package notifier
+
+import "time"
+
+// Event identifies the kind of a structured Message, following the ntfy convention
+const (
+	EventOpen      = "open"
+	EventKeepalive = "keepalive"
+	EventMessage   = "message"
+)
+
+// Attachment is a named blob carried alongside a Message
+type Attachment struct {
+	Name string
+	Data []byte
+}
+
+// Message is a structured notification: a title/body pair plus ntfy-style routing
+// metadata (Priority, Tags), an optional HTML body, and Attachments. Senders
+// translate it to their own wire format.
+type Message struct {
+	ID          string
+	Time        time.Time
+	Event       string // EventOpen, EventKeepalive or EventMessage
+	Topic       string
+	Priority    int // 1 (min) to 5 (max), 0 means unset
+	Tags        []string
+	Title       string
+	Body        string
+	HTMLBody    string
+	Attachments []Attachment
+}
@@ -0,0 +1,49 @@
+package notifier
+
+// Notification is anything that can be rendered for the mail, push, and webhook
+// channels; a nil return from any To* method means that channel is skipped.
+type Notification interface {
+	ToMail() *Mail
+	ToPush() *Push
+	ToWebhook() *Webhook
+	Name() string
+}
+
+// Mail is the email channel rendering of a Notification
+type Mail struct {
+	Subject string
+	Body    string
+	HTML    string
+}
+
+// Push is the push channel rendering of a Notification
+type Push struct {
+	Title string
+	Body  string
+}
+
+// Webhook is the chat-webhook channel rendering of a Notification
+type Webhook struct {
+	Subject string
+	Body    string
+}
+
+// PrioritizedNotification is implemented by Notifications that carry a priority
+// level (1-5, ntfy-style); recipients can filter on it via PriorityRangeNotifiable
+type PrioritizedNotification interface {
+	Priority() int
+}
+
+// Notifiable is a recipient that knows how to route itself for mail and push, and
+// gets a last-minute veto over whether it wants a given Notification
+type Notifiable interface {
+	RouteForMail() (address string, err error)
+	RouteForPush() (token string, platform int, err error)
+	ShouldNotify(n Notification) (bool, error)
+}
+
+// PriorityRangeNotifiable is implemented by Notifiable recipients who only want
+// notifications whose PrioritizedNotification.Priority() falls within [min, max]
+type PriorityRangeNotifiable interface {
+	MaxPrioIn() (min int, max int)
+}
@@ -1,15 +1,14 @@
 package notifier
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
-
-	"github.com/sendgrid/sendgrid-go"
-	"github.com/sendgrid/sendgrid-go/helpers/mail"
 )
 
 const (
@@ -18,16 +17,37 @@ const (
 	NotifyOnceDay  int = 2
 )
 
-// Notifier sends email notification by SendGrid tagged by MessageTag with Frequency
+// Notifier sends notifications through a pluggable Sender and PushSender, deduplicated by MessageTag with Frequency
 type Notifier struct {
-	ApiHost          string // Default is "https://api.sendgrid.com"
-	ApiKey           string // SendGrid API key, required
+	Sender           Sender
+	PushSender       PushSender
+	WebhookSender    *WebhookSender // Used by Dispatch for Notification.ToWebhook
 	Log              *log.Logger
-	From             Recipient
-	To               []Recipient // Required
+	To               []Target // Required, mix of Recipient and Device
 	Frequency        int
 	MessageTag       string
-	DatabaseFilePath string
+	DatabaseFilePath string // Used by the default JSONFileStore when Store is not set
+
+	// Store is the dedup backend; defaults to a JSONFileStore at DatabaseFilePath
+	Store DedupStore
+
+	// DedupByPriorityAndTags folds Message.Priority and Message.Tags into the dedup
+	// hash, so "once per day per (tag, priority)" is possible instead of only per MessageTag
+	DedupByPriorityAndTags bool
+
+	// Recipients is who Dispatch notifies; when empty, DefaultRecipients is used instead
+	Recipients []Notifiable
+	// DefaultRecipients is the fallback notify list Dispatch uses when Recipients is empty
+	DefaultRecipients []Notifiable
+
+	// Templates is the directory of html/template files SendTemplate renders from;
+	// defaults to the built-in notice/alert/digest set when nil
+	Templates *Templates
+}
+
+// Target is implemented by anything Notifier.To can hold: a Recipient (email) or a Device (push)
+type Target interface {
+	isTarget()
 }
 
 // Recipient holds Title and email Address
@@ -36,66 +56,134 @@ type Recipient struct {
 	Address string
 }
 
-// Send message with subject
+func (Recipient) isTarget() {}
+
+// NewNotifier builds a Notifier that delivers through sender to the given targets
+func NewNotifier(sender Sender, to []Target) *Notifier {
+	return &Notifier{
+		Sender: sender,
+		To:     to,
+	}
+}
+
+// Send message with subject. Kept for backward compatibility; it builds a plain
+// Message and delegates to SendMessage, prefer calling SendMessage directly.
 func (notifier Notifier) Send(subject string, message string) error {
+	return notifier.SendMessage(Message{
+		Event: EventMessage,
+		Title: subject,
+		Body:  message,
+	})
+}
+
+// SendMessage dispatches msg to the configured Sender and/or PushSender, deduplicated
+// by MessageTag (and optionally Priority/Tags) with Frequency
+func (notifier Notifier) SendMessage(msg Message) error {
 	if err := notifier.setDefaults(); err != nil {
 		return fmt.Errorf("Notifier failed to send message: %s", err)
 	}
 
-	hash := notifier.getHash()
-	if !notifier.needToSend(hash) {
-		notifier.Log.Printf("Skip message %s: %s %s", hash, subject, message)
+	return notifier.dispatchMessage(msg, notifier.getHash(msg))
+}
+
+// SendTemplate renders name.subject.tpl and name.body.html/name.body.txt from
+// Templates (or the built-in set if Templates is nil) with data, then sends the
+// result the same way SendMessage does. The dedup hash is template-name + tag +
+// a hash of the rendered subject, so distinct renders of the same template aren't
+// collapsed together.
+func (notifier Notifier) SendTemplate(name string, data interface{}) error {
+	if err := notifier.setDefaults(); err != nil {
+		return fmt.Errorf("Notifier failed to send template %s: %s", name, err)
+	}
+
+	templates := notifier.Templates
+	if templates == nil {
+		templates = NewTemplates()
+	}
+
+	subject, err := templates.renderSubject(name, data)
+	if err != nil {
+		return fmt.Errorf("Notifier failed to send template %s: %s", name, err)
+	}
+
+	body, htmlBody, err := templates.renderBody(name, data)
+	if err != nil {
+		return fmt.Errorf("Notifier failed to send template %s: %s", name, err)
+	}
+
+	msg := Message{Event: EventMessage, Title: subject, Body: body, HTMLBody: htmlBody}
+	hash := fmt.Sprintf("%s:%s:%x", name, notifier.MessageTag, sha256.Sum256([]byte(subject)))
+
+	return notifier.dispatchMessage(msg, hash)
+}
+
+func (notifier Notifier) dispatchMessage(msg Message, hash string) error {
+	won, err := notifier.claim(hash, msg.Title)
+	if err != nil {
+		notifier.Log.Printf("Notifier failed to claim %s: %s", hash, err)
+		return err
+	}
+	if !won {
+		notifier.Log.Printf("Skip message %s: %s %s", hash, msg.Title, msg.Body)
 		return nil
 	}
 
-	notifier.Log.Printf("Send message %s: %s %s", notifier.MessageTag, subject, message)
+	notifier.Log.Printf("Send message %s: %s %s", notifier.MessageTag, msg.Title, msg.Body)
 
-	from := mail.NewEmail(notifier.From.Title, notifier.From.Address)
-	for _, recipient := range notifier.To {
-		to := mail.NewEmail(recipient.Title, recipient.Address)
-		content := mail.NewContent("text/plain", message)
-		m := mail.NewV3MailInit(from, subject, to, content)
+	ctx := context.Background()
 
-		request := sendgrid.GetRequest(notifier.ApiKey, "/v3/mail/send", notifier.ApiHost)
-		request.Method = "POST"
-		request.Body = mail.GetRequestBody(m)
-		response, err := sendgrid.API(request)
-		if err != nil {
+	if recipients := notifier.recipients(); len(recipients) > 0 {
+		if err := notifier.Sender.Send(ctx, msg, recipients); err != nil {
 			notifier.Log.Printf("Notifier failed to send message: %s", err)
 			return err
 		}
-		notifier.Log.Printf("Message sent: %s", response)
 	}
 
-	err := notifier.addToDb(hash, subject)
-	if err != nil {
-		notifier.Log.Printf("Notifier failed to send message: %s", err)
-		return err
+	if devices := notifier.devices(); len(devices) > 0 {
+		if notifier.PushSender == nil {
+			notifier.Log.Printf("Notifier failed to send message: PushSender is not set")
+			return errors.New("PushSender is not set")
+		}
+		if err := notifier.PushSender.Push(ctx, msg, devices); err != nil {
+			notifier.Log.Printf("Notifier failed to send message: %s", err)
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (notifier Notifier) setDefaults() error {
-	if notifier.ApiKey == "" {
-		return errors.New("SendGrid API key is not set")
+// recipients returns the email Recipient entries in To
+func (notifier Notifier) recipients() (recipients []Recipient) {
+	for _, target := range notifier.To {
+		if recipient, ok := target.(Recipient); ok {
+			recipients = append(recipients, recipient)
+		}
 	}
+	return
+}
 
-	if len(notifier.To) == 0 {
-		return errors.New("Recipients are not set")
+// devices returns the push Device entries in To
+func (notifier Notifier) devices() (devices []Device) {
+	for _, target := range notifier.To {
+		if device, ok := target.(Device); ok {
+			devices = append(devices, device)
+		}
 	}
+	return
+}
 
-	if notifier.From.Address == "" {
-		notifier.From.Address = "no-reply@no-where.tld"
-		notifier.From.Title = "SendGrid Notifier"
+func (notifier Notifier) setDefaults() error {
+	if notifier.Sender == nil && notifier.PushSender == nil {
+		return errors.New("Sender or PushSender is not set")
 	}
 
-	if notifier.ApiHost == "" {
-		notifier.ApiHost = "https://api.sendgrid.com"
+	if len(notifier.To) == 0 {
+		return errors.New("Recipients are not set")
 	}
 
 	if notifier.Log == nil {
-		notifier.Log = log.New(os.Stdout, "SendGrid Notifier ", log.LstdFlags)
+		notifier.Log = log.New(os.Stdout, "Notifier ", log.LstdFlags)
 	}
 
 	if notifier.MessageTag == "" {
@@ -109,118 +197,78 @@ func (notifier Notifier) setDefaults() error {
 	return nil
 }
 
-func (notifier Notifier) needToSend(hash string) bool {
-	if notifier.Frequency == NotifyAlways {
-		return true
-	}
-
-	if notifier.inDb(hash) {
-		return false
-	}
-
-	return true
-}
-
-func (notifier Notifier) getHash() (hash string) {
+func (notifier Notifier) getHash(msg Message) (hash string) {
 	t := time.Now()
+	tag := notifier.MessageTag
+	if notifier.DedupByPriorityAndTags {
+		tag = fmt.Sprintf("%s:%d:%s", notifier.MessageTag, msg.Priority, strings.Join(msg.Tags, ","))
+	}
 
 	if notifier.Frequency == NotifyOnceHour {
-		hash = t.Format("2006-01-02-15") + ":" + notifier.MessageTag
+		hash = t.Format("2006-01-02-15") + ":" + tag
 		return
 	}
 
 	if notifier.Frequency == NotifyOnceDay {
-		hash = t.Format("2006-01-02") + ":" + notifier.MessageTag
+		hash = t.Format("2006-01-02") + ":" + tag
 		return
 	}
 
 	return
 }
 
-func (notifier Notifier) addToDb(hash string, subject string) error {
-	db, err := notifier.loadDb()
-	if err != nil {
-		return fmt.Errorf("Notifier failed to add %s:%s to database: %s", hash, subject, err)
-	}
-
-	db[hash] = subject
-
-	err = notifier.saveDb(db)
-	if err != nil {
-		return fmt.Errorf("Notifier failed to add %s to database: %s", hash, err)
-	}
-
-	return nil
-}
+// getNotificationHash is getHash's counterpart for Dispatch, keyed by the
+// Notification's Name instead of a rendered Message
+func (notifier Notifier) getNotificationHash(n Notification) (hash string) {
+	t := time.Now()
+	tag := notifier.MessageTag + ":" + n.Name()
 
-func (notifier Notifier) inDb(hash string) bool {
-	db, err := notifier.loadDb()
-	if err != nil {
-		notifier.Log.Printf("Notifier failed to load database: %s", err)
-		return false
+	if notifier.Frequency == NotifyOnceHour {
+		hash = t.Format("2006-01-02-15") + ":" + tag
+		return
 	}
 
-	if _, ok := db[hash]; ok == true {
-		return true
+	if notifier.Frequency == NotifyOnceDay {
+		hash = t.Format("2006-01-02") + ":" + tag
+		return
 	}
 
-	return false
+	return
 }
 
-func (notifier Notifier) saveDb(db map[string]string) (err error) {
-	if notifier.DatabaseFilePath == "" {
-		notifier.DatabaseFilePath = "notifier.json"
-	}
-
-	dbFile, err := os.Open(notifier.DatabaseFilePath)
-	if err != nil {
-		return fmt.Errorf("Notifier failed to save database: %s", err)
-	}
-	defer dbFile.Close()
-
-	err = json.NewEncoder(dbFile).Encode(db)
-	if err != nil {
-		return fmt.Errorf("Notifier failed to save database: %s", err)
+// claim reports whether hash should be sent now: NotifyAlways always wins without
+// touching the store, otherwise the first caller to claim hash within the current
+// window proceeds and later callers for the same hash are skipped
+func (notifier Notifier) claim(hash string, subject string) (bool, error) {
+	if notifier.Frequency == NotifyAlways {
+		return true, nil
 	}
 
-	return
+	return notifier.store().Claim(hash, subject, notifier.ttl())
 }
 
-func (notifier Notifier) loadDb() (db map[string]string, err error) {
-	if notifier.DatabaseFilePath == "" {
-		notifier.DatabaseFilePath = "notifier.json"
-	}
-
-	dbFile, err := os.Open(notifier.DatabaseFilePath)
-	if err != nil {
-		dbFile, err = notifier.createDb()
-		if err != nil {
-			return nil, fmt.Errorf("Notifier failed to load database: %s", err)
-		}
+// store returns the configured DedupStore, defaulting to a JSONFileStore at DatabaseFilePath
+func (notifier Notifier) store() DedupStore {
+	if notifier.Store != nil {
+		return notifier.Store
 	}
-	defer dbFile.Close()
 
-	db = make(map[string]string)
-	err = json.NewDecoder(dbFile).Decode(&db)
-	if err != nil {
-		return nil, fmt.Errorf("Notifier failed to load database: %s", err)
+	path := notifier.DatabaseFilePath
+	if path == "" {
+		path = "notifier.json"
 	}
 
-	return
+	return NewJSONFileStore(path)
 }
 
-func (notifier Notifier) createDb() (dbFile *os.File, err error) {
-	dbFile, err = os.Create(notifier.DatabaseFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("Notifier failed to create database file %s: %s", notifier.DatabaseFilePath, err)
-	}
-	err = dbFile.Truncate(0)
-	if err != nil {
-		return nil, fmt.Errorf("Notifier failed to create database file %s: %s", notifier.DatabaseFilePath, err)
+// ttl derives a dedup expiry from Frequency so stores can auto-expire old entries
+func (notifier Notifier) ttl() time.Duration {
+	switch notifier.Frequency {
+	case NotifyOnceHour:
+		return time.Hour
+	case NotifyOnceDay:
+		return 24 * time.Hour
+	default:
+		return 0
 	}
-	_, err = dbFile.Write([]byte("{}"))
-	if err != nil {
-		return nil, fmt.Errorf("Notifier failed to create database file %s: %s", notifier.DatabaseFilePath, err)
-	}
-	return
 }
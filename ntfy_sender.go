@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NtfySender publishes notifications to a ntfy.sh topic over HTTP
+type NtfySender struct {
+	ServerURL string // Default is "https://ntfy.sh"
+	Topic     string // Required
+	Token     string // optional access token for protected topics
+}
+
+// Send implements Sender by POSTing msg.Body as the body of a ntfy publish request,
+// carrying Title/Priority/Tags as the X-Title/X-Priority/X-Tags headers
+func (sender *NtfySender) Send(ctx context.Context, msg Message, recipients []Recipient) error {
+	sender.setDefaults()
+
+	topic := msg.Topic
+	if topic == "" {
+		topic = sender.Topic
+	}
+
+	endpoint := fmt.Sprintf("%s/%s", strings.TrimRight(sender.ServerURL, "/"), topic)
+	request, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(msg.Body))
+	if err != nil {
+		return fmt.Errorf("NtfySender failed to build request: %s", err)
+	}
+	if msg.Title != "" {
+		request.Header.Set("X-Title", msg.Title)
+	}
+	if msg.Priority != 0 {
+		request.Header.Set("X-Priority", fmt.Sprintf("%d", msg.Priority))
+	}
+	if len(msg.Tags) > 0 {
+		request.Header.Set("X-Tags", strings.Join(msg.Tags, ","))
+	}
+	if sender.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+sender.Token)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("NtfySender failed to publish message: %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("NtfySender failed to publish message: unexpected status %s", response.Status)
+	}
+
+	return nil
+}
+
+func (sender *NtfySender) setDefaults() {
+	if sender.ServerURL == "" {
+		sender.ServerURL = "https://ntfy.sh"
+	}
+}
@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a DedupStore backed by Redis, using SET NX EX for atomic
+// once-per-window semantics across any number of processes
+type RedisStore struct {
+	Client *redis.Client
+	Prefix string // key prefix, default "notifier:dedup:"
+}
+
+// NewRedisStore builds a RedisStore around an existing client
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{Client: client}
+}
+
+// Seen reports whether hash has an unexpired entry
+func (store *RedisStore) Seen(hash string) bool {
+	n, err := store.Client.Exists(context.Background(), store.key(hash)).Result()
+	if err != nil {
+		return false
+	}
+
+	return n > 0
+}
+
+// Claim atomically checks hash and, if it has no unexpired entry, marks it as seen.
+// SET NX makes the check-and-set a single atomic operation, so of any number of
+// callers racing on the same hash across any number of processes, exactly one wins.
+func (store *RedisStore) Claim(hash string, subject string, ttl time.Duration) (bool, error) {
+	won, err := store.Client.SetNX(context.Background(), store.key(hash), subject, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("RedisStore failed to claim %s: %s", hash, err)
+	}
+
+	return won, nil
+}
+
+// Close closes the underlying Redis client
+func (store *RedisStore) Close() error {
+	return store.Client.Close()
+}
+
+func (store *RedisStore) key(hash string) string {
+	prefix := store.Prefix
+	if prefix == "" {
+		prefix = "notifier:dedup:"
+	}
+
+	return prefix + hash
+}
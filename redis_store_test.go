@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisStore(t *testing.T) *RedisStore {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close()
+		t.Skipf("redis not available at localhost:6379: %s", err)
+	}
+
+	store := NewRedisStore(client)
+	store.Prefix = "notifier:dedup:test:" + t.Name() + ":"
+	t.Cleanup(func() {
+		client.Del(context.Background(), store.key("hash"))
+		store.Close()
+	})
+
+	return store
+}
+
+func TestRedisStoreClaim(t *testing.T) {
+	store := newTestRedisStore(t)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("first Claim should win")
+	}
+
+	won, err = store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if won {
+		t.Fatal("second Claim before expiry should lose")
+	}
+
+	if !store.Seen("hash") {
+		t.Fatal("Seen should report the claimed hash")
+	}
+}
+
+func TestRedisStoreClaimExpiry(t *testing.T) {
+	store := newTestRedisStore(t)
+
+	if _, err := store.Claim("hash", "subject", 50*time.Millisecond); err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("Claim should win again once the prior entry expired")
+	}
+}
@@ -0,0 +1,13 @@
+package notifier
+
+import "context"
+
+// Sender delivers a Message to recipients over a specific transport.
+type Sender interface {
+	Send(ctx context.Context, msg Message, recipients []Recipient) error
+}
+
+// PushSender delivers a Message to mobile devices over a push transport.
+type PushSender interface {
+	Push(ctx context.Context, msg Message, devices []Device) error
+}
@@ -0,0 +1,64 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+// SendGridSender delivers email notifications through the SendGrid v3 mail API
+type SendGridSender struct {
+	ApiHost string // Default is "https://api.sendgrid.com"
+	ApiKey  string // SendGrid API key, required
+	From    Recipient
+}
+
+// Send implements Sender by posting to the SendGrid v3 mail/send endpoint,
+// carrying msg.HTMLBody as an alternate content and msg.Tags as categories
+func (sender *SendGridSender) Send(ctx context.Context, msg Message, recipients []Recipient) error {
+	if err := sender.setDefaults(); err != nil {
+		return fmt.Errorf("SendGridSender failed to send message: %s", err)
+	}
+
+	from := mail.NewEmail(sender.From.Title, sender.From.Address)
+	for _, recipient := range recipients {
+		to := mail.NewEmail(recipient.Title, recipient.Address)
+		content := mail.NewContent("text/plain", msg.Body)
+		m := mail.NewV3MailInit(from, msg.Title, to, content)
+		if msg.HTMLBody != "" {
+			m.AddContent(mail.NewContent("text/html", msg.HTMLBody))
+		}
+		if len(msg.Tags) > 0 {
+			m.AddCategories(msg.Tags...)
+		}
+
+		request := sendgrid.GetRequest(sender.ApiKey, "/v3/mail/send", sender.ApiHost)
+		request.Method = "POST"
+		request.Body = mail.GetRequestBody(m)
+		if _, err := sendgrid.API(request); err != nil {
+			return fmt.Errorf("SendGridSender failed to send message: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (sender *SendGridSender) setDefaults() error {
+	if sender.ApiKey == "" {
+		return errors.New("SendGrid API key is not set")
+	}
+
+	if sender.From.Address == "" {
+		sender.From.Address = "no-reply@no-where.tld"
+		sender.From.Title = "SendGrid Notifier"
+	}
+
+	if sender.ApiHost == "" {
+		sender.ApiHost = "https://api.sendgrid.com"
+	}
+
+	return nil
+}
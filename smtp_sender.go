@@ -0,0 +1,166 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+)
+
+// SMTPSender delivers email notifications directly through an SMTP relay,
+// mirroring the nightingale-style SMTP config (host/port/user/pass/TLS)
+type SMTPSender struct {
+	Host               string
+	Port               int
+	User               string
+	Pass               string
+	From               Recipient
+	TLS                bool
+	InsecureSkipVerify bool
+}
+
+// Send implements Sender by dialing the configured SMTP server and sending msg as a
+// MIME multipart message, carrying msg.HTMLBody and msg.Attachments when present
+func (sender *SMTPSender) Send(ctx context.Context, msg Message, recipients []Recipient) error {
+	if len(recipients) == 0 {
+		return errors.New("SMTPSender failed to send message: no recipients")
+	}
+
+	addr := net.JoinHostPort(sender.Host, fmt.Sprintf("%d", sender.Port))
+	to := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		to = append(to, recipient.Address)
+	}
+	body, err := sender.buildBody(to, msg)
+	if err != nil {
+		return fmt.Errorf("SMTPSender failed to build message: %s", err)
+	}
+
+	var auth smtp.Auth
+	if sender.User != "" {
+		auth = smtp.PlainAuth("", sender.User, sender.Pass, sender.Host)
+	}
+
+	if sender.TLS {
+		if err := sender.sendTLS(addr, auth, to, body); err != nil {
+			return fmt.Errorf("SMTPSender failed to send message: %s", err)
+		}
+		return nil
+	}
+
+	if err := smtp.SendMail(addr, auth, sender.From.Address, to, body); err != nil {
+		return fmt.Errorf("SMTPSender failed to send message: %s", err)
+	}
+
+	return nil
+}
+
+func (sender *SMTPSender) sendTLS(addr string, auth smtp.Auth, to []string, body []byte) error {
+	conn, err := tls.Dial("tcp", addr, &tls.Config{
+		ServerName:         sender.Host,
+		InsecureSkipVerify: sender.InsecureSkipVerify,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dial %s: %s", addr, err)
+	}
+	defer conn.Close()
+
+	client, err := smtp.NewClient(conn, sender.Host)
+	if err != nil {
+		return fmt.Errorf("failed to start client: %s", err)
+	}
+	defer client.Close()
+
+	if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("failed to authenticate: %s", err)
+		}
+	}
+
+	if err := client.Mail(sender.From.Address); err != nil {
+		return fmt.Errorf("failed to set sender: %s", err)
+	}
+	for _, addr := range to {
+		if err := client.Rcpt(addr); err != nil {
+			return fmt.Errorf("failed to add recipient %s: %s", addr, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("failed to open data writer: %s", err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("failed to write message body: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to close data writer: %s", err)
+	}
+
+	return client.Quit()
+}
+
+func (sender *SMTPSender) buildBody(to []string, msg Message) ([]byte, error) {
+	var parts bytes.Buffer
+	writer := multipart.NewWriter(&parts)
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := textPart.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+
+	if msg.HTMLBody != "" {
+		htmlPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := htmlPart.Write([]byte(msg.HTMLBody)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, attachment := range msg.Attachments {
+		attachmentPart, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"application/octet-stream"},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {fmt.Sprintf(`attachment; filename="%s"`, attachment.Name)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := attachmentPart.Write([]byte(base64.StdEncoding.EncodeToString(attachment.Data))); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("From: %s <%s>\r\n", sanitizeHeader(sender.From.Title), sanitizeHeader(sender.From.Address)))
+	b.WriteString(fmt.Sprintf("To: %s\r\n", sanitizeHeader(strings.Join(to, ", "))))
+	b.WriteString(fmt.Sprintf("Subject: %s\r\n", sanitizeHeader(msg.Title)))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%s\r\n", writer.Boundary()))
+	b.WriteString("\r\n")
+	b.Write(parts.Bytes())
+
+	return []byte(b.String()), nil
+}
+
+// sanitizeHeader strips CR/LF so a caller-supplied title or address can't inject
+// extra headers or recipients into the message via header splitting
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
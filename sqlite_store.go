@@ -0,0 +1,101 @@
+package notifier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteStore is a DedupStore backed by a SQLite database, relying on SQLite's own
+// file locking to stay safe across concurrent processes
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (creating if needed) a SQLite database at path
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("SQLiteStore failed to open %s: %s", path, err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS notifier_dedup (
+		hash TEXT PRIMARY KEY,
+		subject TEXT NOT NULL,
+		expires_at DATETIME
+	)`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("SQLiteStore failed to create table: %s", err)
+	}
+
+	return &SQLiteStore{db: db}, nil
+}
+
+// Seen reports whether hash has an unexpired entry
+func (store *SQLiteStore) Seen(hash string) bool {
+	var expiresAt sql.NullTime
+	row := store.db.QueryRow(`SELECT expires_at FROM notifier_dedup WHERE hash = ?`, hash)
+	if err := row.Scan(&expiresAt); err != nil {
+		return false
+	}
+
+	return !expiresAt.Valid || time.Now().Before(expiresAt.Time)
+}
+
+// Claim atomically checks hash and, if it has no unexpired entry, marks it as seen.
+// The check and the upsert run on a single connection inside a BEGIN IMMEDIATE
+// transaction, which takes SQLite's write lock up front instead of on first write,
+// closing the window where two connections could both observe hash as unseen.
+func (store *SQLiteStore) Claim(hash string, subject string, ttl time.Duration) (bool, error) {
+	ctx := context.Background()
+
+	conn, err := store.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("SQLiteStore failed to claim %s: %s", hash, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `BEGIN IMMEDIATE`); err != nil {
+		return false, fmt.Errorf("SQLiteStore failed to claim %s: %s", hash, err)
+	}
+	defer conn.ExecContext(ctx, `ROLLBACK`)
+
+	var expiresAt sql.NullTime
+	row := conn.QueryRowContext(ctx, `SELECT expires_at FROM notifier_dedup WHERE hash = ?`, hash)
+	switch err := row.Scan(&expiresAt); {
+	case err == sql.ErrNoRows:
+		// not seen, fall through and claim it
+	case err != nil:
+		return false, fmt.Errorf("SQLiteStore failed to claim %s: %s", hash, err)
+	case !expiresAt.Valid || time.Now().Before(expiresAt.Time):
+		return false, nil
+	}
+
+	var newExpiresAt *time.Time
+	if ttl > 0 {
+		t := time.Now().Add(ttl)
+		newExpiresAt = &t
+	}
+
+	_, err = conn.ExecContext(ctx, `INSERT INTO notifier_dedup (hash, subject, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT(hash) DO UPDATE SET subject = excluded.subject, expires_at = excluded.expires_at`,
+		hash, subject, newExpiresAt)
+	if err != nil {
+		return false, fmt.Errorf("SQLiteStore failed to claim %s: %s", hash, err)
+	}
+
+	if _, err := conn.ExecContext(ctx, `COMMIT`); err != nil {
+		return false, fmt.Errorf("SQLiteStore failed to claim %s: %s", hash, err)
+	}
+
+	return true, nil
+}
+
+// Close releases the underlying database handle
+func (store *SQLiteStore) Close() error {
+	return store.db.Close()
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "dedup.sqlite"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore: %s", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	return store
+}
+
+func TestSQLiteStoreClaim(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("first Claim should win")
+	}
+
+	won, err = store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if won {
+		t.Fatal("second Claim before expiry should lose")
+	}
+
+	if !store.Seen("hash") {
+		t.Fatal("Seen should report the claimed hash")
+	}
+}
+
+func TestSQLiteStoreClaimExpiry(t *testing.T) {
+	store := newTestSQLiteStore(t)
+
+	if _, err := store.Claim("hash", "subject", time.Millisecond); err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	won, err := store.Claim("hash", "subject", time.Hour)
+	if err != nil {
+		t.Fatalf("Claim: %s", err)
+	}
+	if !won {
+		t.Fatal("Claim should win again once the prior entry expired")
+	}
+}
@@ -0,0 +1,96 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	textTemplate "text/template"
+)
+
+//go:embed templates/*.tpl templates/*.html templates/*.txt
+var builtinTemplates embed.FS
+
+// Templates renders SendTemplate's name.subject.tpl plus name.body.html and/or
+// name.body.txt out of FS, which is either an embed.FS of built-ins or
+// os.DirFS(dir) for caller-supplied templates. When FS holds a base.html partial,
+// every HTML body is rendered through it, so each body must define a "content"
+// template that base.html pulls in with {{template "content" .}}.
+type Templates struct {
+	FS fs.FS
+}
+
+// NewTemplates builds a Templates backed by the built-in notice/alert/digest set
+func NewTemplates() *Templates {
+	sub, err := fs.Sub(builtinTemplates, "templates")
+	if err != nil {
+		panic(err) // built-in templates are embedded at compile time and always valid
+	}
+
+	return &Templates{FS: sub}
+}
+
+func (t *Templates) renderSubject(name string, data interface{}) (string, error) {
+	return t.renderText(name+".subject.tpl", data)
+}
+
+func (t *Templates) renderBody(name string, data interface{}) (body string, html string, err error) {
+	if t.exists(name + ".body.html") {
+		if html, err = t.renderHTML(name+".body.html", data); err != nil {
+			return "", "", err
+		}
+	}
+
+	if t.exists(name + ".body.txt") {
+		if body, err = t.renderText(name+".body.txt", data); err != nil {
+			return "", "", err
+		}
+	}
+
+	if body == "" && html == "" {
+		return "", "", fmt.Errorf("no %s.body.html or %s.body.txt template found", name, name)
+	}
+
+	return body, html, nil
+}
+
+func (t *Templates) exists(filename string) bool {
+	_, err := fs.Stat(t.FS, filename)
+	return err == nil
+}
+
+func (t *Templates) renderText(filename string, data interface{}) (string, error) {
+	tpl, err := textTemplate.ParseFS(t.FS, filename)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func (t *Templates) renderHTML(filename string, data interface{}) (string, error) {
+	names := []string{filename}
+	root := filename
+	if t.exists("base.html") {
+		names = []string{"base.html", filename}
+		root = "base.html"
+	}
+
+	tpl, err := template.ParseFS(t.FS, names...)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, root, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
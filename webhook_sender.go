@@ -0,0 +1,105 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookPayloadBuilder builds a provider-specific JSON payload for a chat webhook
+type WebhookPayloadBuilder func(msg Message) interface{}
+
+// WebhookSender posts a JSON payload built by PayloadBuilder to a chat webhook URL
+type WebhookSender struct {
+	URL            string
+	PayloadBuilder WebhookPayloadBuilder
+}
+
+// Send implements Sender by POSTing the built payload as JSON to URL
+func (sender *WebhookSender) Send(ctx context.Context, msg Message, recipients []Recipient) error {
+	if sender.PayloadBuilder == nil {
+		return fmt.Errorf("WebhookSender failed to send message: PayloadBuilder is not set")
+	}
+
+	payload, err := json.Marshal(sender.PayloadBuilder(msg))
+	if err != nil {
+		return fmt.Errorf("WebhookSender failed to encode payload: %s", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", sender.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("WebhookSender failed to build request: %s", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("WebhookSender failed to send message: %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("WebhookSender failed to send message: unexpected status %s", response.Status)
+	}
+
+	return nil
+}
+
+// NewSlackWebhookSender builds a WebhookSender posting Slack incoming-webhook payloads
+func NewSlackWebhookSender(url string) *WebhookSender {
+	return &WebhookSender{
+		URL: url,
+		PayloadBuilder: func(msg Message) interface{} {
+			return map[string]string{
+				"text": fmt.Sprintf("*%s*\n%s", msg.Title, msg.Body),
+			}
+		},
+	}
+}
+
+// NewDingTalkWebhookSender builds a WebhookSender posting DingTalk custom robot payloads
+func NewDingTalkWebhookSender(url string) *WebhookSender {
+	return &WebhookSender{
+		URL: url,
+		PayloadBuilder: func(msg Message) interface{} {
+			return map[string]interface{}{
+				"msgtype": "text",
+				"text": map[string]string{
+					"content": fmt.Sprintf("%s\n%s", msg.Title, msg.Body),
+				},
+			}
+		},
+	}
+}
+
+// NewFeishuWebhookSender builds a WebhookSender posting Feishu (Lark) custom bot payloads
+func NewFeishuWebhookSender(url string) *WebhookSender {
+	return &WebhookSender{
+		URL: url,
+		PayloadBuilder: func(msg Message) interface{} {
+			return map[string]interface{}{
+				"msg_type": "text",
+				"content": map[string]string{
+					"text": fmt.Sprintf("%s\n%s", msg.Title, msg.Body),
+				},
+			}
+		},
+	}
+}
+
+// NewWeComWebhookSender builds a WebhookSender posting WeCom (WeChat Work) group robot payloads
+func NewWeComWebhookSender(url string) *WebhookSender {
+	return &WebhookSender{
+		URL: url,
+		PayloadBuilder: func(msg Message) interface{} {
+			return map[string]interface{}{
+				"msgtype": "text",
+				"text": map[string]string{
+					"content": fmt.Sprintf("%s\n%s", msg.Title, msg.Body),
+				},
+			}
+		},
+	}
+}